@@ -0,0 +1,41 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"istio.io/istio/pkg/env"
+)
+
+var (
+	// EndpointSinks is a comma-separated list of external service registries that
+	// controller.endpointSliceController should mirror discovered Kubernetes endpoints into (for
+	// example "consul"). Empty disables endpoint mirroring entirely. Unknown names, and sinks not
+	// compiled into this binary via their build tag, are skipped with a warning.
+	EndpointSinks = env.Register(
+		"ENDPOINT_SINKS",
+		"",
+		"Comma-separated list of external service registries to mirror Kubernetes EndpointSlice "+
+			"changes into.",
+	).Get()
+
+	// EndpointSinkConsulAddress overrides the default local agent address the consul endpoint
+	// sink registers services with. Empty uses the consul client's default address resolution
+	// (the CONSUL_HTTP_ADDR environment variable, or http://127.0.0.1:8500).
+	EndpointSinkConsulAddress = env.Register(
+		"ENDPOINT_SINK_CONSUL_ADDRESS",
+		"",
+		"Address of the Consul agent the consul endpoint sink registers services with.",
+	).Get()
+)
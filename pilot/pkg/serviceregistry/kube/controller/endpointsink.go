@@ -0,0 +1,115 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/host"
+)
+
+// EndpointSink mirrors Kubernetes endpoints discovered by endpointSliceController into an
+// external service registry - HashiCorp Consul, Nacos, or a generic HTTP/gRPC sink - the way
+// projects like kube2consul do. Implementations must be safe for concurrent use and idempotent,
+// since Register/Deregister may be called more than once for the same endpoint.
+type EndpointSink interface {
+	// Register upserts endpoint as an instance of the service named hostName.
+	Register(hostName host.Name, endpoint *model.IstioEndpoint) error
+	// Deregister removes endpoint from the service named hostName.
+	Deregister(hostName host.Name, endpoint *model.IstioEndpoint) error
+	// Sync reconciles the sink's view of hostName with the full, current endpoint set. It is
+	// called once on startup, after the EndpointSlice informer has synced, to recover from any
+	// Register/Deregister calls that were missed before then; a later sink reconnect does not
+	// trigger it.
+	Sync(hostName host.Name, endpoints []*model.IstioEndpoint) error
+}
+
+// multiEndpointSink fans Register/Deregister/Sync out to every configured sink, so endpoints can
+// be mirrored to more than one external registry (e.g. Consul and a generic HTTP sink) at once
+// without any single sink needing to know about the others.
+type multiEndpointSink []EndpointSink
+
+func (m multiEndpointSink) Register(hostName host.Name, endpoint *model.IstioEndpoint) error {
+	return m.forEach(func(s EndpointSink) error { return s.Register(hostName, endpoint) })
+}
+
+func (m multiEndpointSink) Deregister(hostName host.Name, endpoint *model.IstioEndpoint) error {
+	return m.forEach(func(s EndpointSink) error { return s.Deregister(hostName, endpoint) })
+}
+
+func (m multiEndpointSink) Sync(hostName host.Name, endpoints []*model.IstioEndpoint) error {
+	return m.forEach(func(s EndpointSink) error { return s.Sync(hostName, endpoints) })
+}
+
+func (m multiEndpointSink) forEach(fn func(EndpointSink) error) error {
+	var errs []string
+	for _, sink := range m {
+		if err := fn(sink); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("endpoint sink: %s", strings.Join(errs, "; "))
+}
+
+// noopEndpointSink is used when no sinks are configured, so call sites never need to nil-check
+// esc.sink.
+type noopEndpointSink struct{}
+
+func (noopEndpointSink) Register(host.Name, *model.IstioEndpoint) error   { return nil }
+func (noopEndpointSink) Deregister(host.Name, *model.IstioEndpoint) error { return nil }
+func (noopEndpointSink) Sync(host.Name, []*model.IstioEndpoint) error     { return nil }
+
+// sinkFactories holds EndpointSink constructors keyed by the name used in
+// features.EndpointSinks. Sinks that pull in a third-party client (e.g. consul) register
+// themselves from an init() in a file gated by their own build tag, so the default
+// pilot-discovery build never links that dependency unless it was built with that tag.
+var sinkFactories = map[string]func() EndpointSink{}
+
+// registerEndpointSink is called from the init() of a build-tag-gated sink implementation file.
+func registerEndpointSink(name string, factory func() EndpointSink) {
+	sinkFactories[name] = factory
+}
+
+// newEndpointSinkFromConfig builds the EndpointSink fan-out configured via the
+// ENDPOINT_SINKS/ENDPOINT_SINK_CONSUL_ADDRESS vars in pilot/pkg/features. (A MeshConfig-based
+// path was considered, but isn't implemented yet - env vars are the only configuration surface
+// today.) Unknown sink names, and sinks whose build tag wasn't compiled in, are skipped with a
+// log rather than failing controller startup, since a typo'd sink name should not take down EDS.
+func newEndpointSinkFromConfig() EndpointSink {
+	var sinks multiEndpointSink
+	for _, name := range strings.Split(features.EndpointSinks, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		factory, ok := sinkFactories[name]
+		if !ok {
+			log.Warnf("unknown or not built-in endpoint sink %q, skipping "+
+				"(e.g. consul requires building pilot-discovery with -tags=consul)", name)
+			continue
+		}
+		sinks = append(sinks, factory())
+	}
+	if len(sinks) == 0 {
+		return noopEndpointSink{}
+	}
+	return sinks
+}
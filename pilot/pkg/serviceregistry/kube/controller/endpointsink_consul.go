@@ -0,0 +1,245 @@
+//go:build consul
+
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is only compiled into pilot-discovery when built with -tags=consul, so a default
+// build never links the Consul client (and its MPL-2.0 license) in unless the consul endpoint
+// sink is actually wanted. See endpointsink.go's sinkFactories registry.
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/host"
+)
+
+func init() {
+	registerEndpointSink("consul", func() EndpointSink {
+		return newConsulEndpointSink(features.EndpointSinkConsulAddress)
+	})
+}
+
+// consulTTL is the TTL consulHealthCheck registers for every check. heartbeatInterval must stay
+// well under this so a missed heartbeat or two doesn't flip Consul to critical.
+const (
+	consulTTL         = 30 * time.Second
+	heartbeatInterval = consulTTL / 3
+)
+
+// consulEndpointSink mirrors discovered endpoints into a Consul catalog, the way kube2consul
+// does. Each IstioEndpoint becomes a Consul service instance named after the hostname, tagged
+// with its Kubernetes labels, with a TTL health check derived from its Ready/Serving-derived
+// HealthStatus. Since EndpointSlice conditions - not a reachable check target - are the source
+// of truth for health, Register starts a heartbeat goroutine that re-asserts the check's status
+// every heartbeatInterval for as long as the endpoint stays registered; otherwise the check would
+// flip to critical once consulTTL elapsed between updates, even for an endpoint Kubernetes still
+// considers perfectly healthy.
+type consulEndpointSink struct {
+	client *consulapi.Client
+
+	mu sync.Mutex
+	// registered tracks the Consul service instance IDs we own per host, so Sync can
+	// deregister instances that are no longer present in the latest endpoint set.
+	registered map[host.Name]map[string]struct{}
+	// heartbeatStop stops the TTL heartbeat goroutine for a given check ID, keyed the same way
+	// as the check's CheckID (see consulCheckID).
+	heartbeatStop map[string]chan struct{}
+}
+
+func newConsulEndpointSink(address string) *consulEndpointSink {
+	cfg := consulapi.DefaultConfig()
+	if address != "" {
+		cfg.Address = address
+	}
+	client, err := consulapi.NewClient(cfg)
+	if err != nil {
+		// Register/Deregister/Sync all surface the same error on every call when the client
+		// failed to construct, so we log once here rather than failing controller startup.
+		log.Errorf("failed to create consul client for endpoint sink: %v", err)
+	}
+	return &consulEndpointSink{
+		client:        client,
+		registered:    make(map[host.Name]map[string]struct{}),
+		heartbeatStop: make(map[string]chan struct{}),
+	}
+}
+
+func (c *consulEndpointSink) Register(hostName host.Name, ep *model.IstioEndpoint) error {
+	if c.client == nil {
+		return fmt.Errorf("consul endpoint sink for %s has no client", hostName)
+	}
+	id := consulServiceInstanceID(hostName, ep)
+	check := consulHealthCheck(ep)
+	check.CheckID = consulCheckID(id)
+	if err := c.client.Agent().ServiceRegister(&consulapi.AgentServiceRegistration{
+		ID:      id,
+		Name:    string(hostName),
+		Address: ep.Address,
+		Port:    int(ep.EndpointPort),
+		Tags:    consulTagsFromLabels(ep.Labels),
+		Check:   check,
+	}); err != nil {
+		return fmt.Errorf("register %s: %w", id, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.registered[hostName] == nil {
+		c.registered[hostName] = make(map[string]struct{})
+	}
+	c.registered[hostName][id] = struct{}{}
+	c.startHeartbeatLocked(check.CheckID, check.Status)
+	return nil
+}
+
+// startHeartbeatLocked (re)starts the TTL heartbeat goroutine for checkID, stopping any existing
+// one first so re-registering an endpoint (e.g. on a health status change) doesn't leak a
+// goroutine. Callers must hold c.mu.
+func (c *consulEndpointSink) startHeartbeatLocked(checkID, status string) {
+	if stop, ok := c.heartbeatStop[checkID]; ok {
+		close(stop)
+	}
+	stop := make(chan struct{})
+	c.heartbeatStop[checkID] = stop
+	go c.heartbeatLoop(checkID, status, stop)
+}
+
+func (c *consulEndpointSink) heartbeatLoop(checkID, status string, stop chan struct{}) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := c.client.Agent().UpdateTTL(checkID, "", status); err != nil {
+				log.Warnf("failed to heartbeat consul TTL check %s: %v", checkID, err)
+			}
+		}
+	}
+}
+
+func (c *consulEndpointSink) Deregister(hostName host.Name, ep *model.IstioEndpoint) error {
+	if c.client == nil {
+		return fmt.Errorf("consul endpoint sink for %s has no client", hostName)
+	}
+	id := consulServiceInstanceID(hostName, ep)
+	if err := c.client.Agent().ServiceDeregister(id); err != nil {
+		return fmt.Errorf("deregister %s: %w", id, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.registered[hostName], id)
+	c.stopHeartbeatLocked(consulCheckID(id))
+	return nil
+}
+
+// stopHeartbeatLocked stops and forgets the heartbeat goroutine for checkID, if any. Callers
+// must hold c.mu.
+func (c *consulEndpointSink) stopHeartbeatLocked(checkID string) {
+	if stop, ok := c.heartbeatStop[checkID]; ok {
+		close(stop)
+		delete(c.heartbeatStop, checkID)
+	}
+}
+
+// Sync reconciles hostName against endpoints in a single pass: every endpoint is (re-)registered,
+// then any previously-registered instance ID absent from the new set is deregistered. This is
+// what recovers a sink's state after a missed update - the controller resyncs once on startup
+// by listing all hosts from endpointSliceCache and calling Sync for each.
+func (c *consulEndpointSink) Sync(hostName host.Name, endpoints []*model.IstioEndpoint) error {
+	if c.client == nil {
+		return fmt.Errorf("consul endpoint sink for %s has no client", hostName)
+	}
+
+	want := make(map[string]struct{}, len(endpoints))
+	var errs []error
+	for _, ep := range endpoints {
+		want[consulServiceInstanceID(hostName, ep)] = struct{}{}
+		if err := c.Register(hostName, ep); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	c.mu.Lock()
+	var stale []string
+	for id := range c.registered[hostName] {
+		if _, stillWanted := want[id]; !stillWanted {
+			stale = append(stale, id)
+		}
+	}
+	c.mu.Unlock()
+	for _, id := range stale {
+		if err := c.client.Agent().ServiceDeregister(id); err != nil {
+			errs = append(errs, fmt.Errorf("deregister %s: %w", id, err))
+			continue
+		}
+		c.mu.Lock()
+		delete(c.registered[hostName], id)
+		c.stopHeartbeatLocked(consulCheckID(id))
+		c.mu.Unlock()
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("consul sync for %s: %v", hostName, errs)
+	}
+	return nil
+}
+
+// consulServiceInstanceID must be stable across calls for the same endpoint so repeated
+// registrations update it in place rather than accumulating duplicates in the Consul catalog.
+func consulServiceInstanceID(hostName host.Name, ep *model.IstioEndpoint) string {
+	return fmt.Sprintf("%s-%s-%d", hostName, ep.Address, ep.EndpointPort)
+}
+
+// consulCheckID is kept distinct from the service instance ID so Consul's default
+// "service:<id>" check-ID convention never collides with ours.
+func consulCheckID(serviceInstanceID string) string {
+	return "ttl:" + serviceInstanceID
+}
+
+func consulTagsFromLabels(labels map[string]string) []string {
+	tags := make([]string, 0, len(labels))
+	for k, v := range labels {
+		tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+	}
+	return tags
+}
+
+// consulHealthCheck derives a TTL health check from the endpoint's Ready/Serving-derived
+// HealthStatus, since EndpointSlice conditions - not a reachable HTTP/TCP target - are the
+// source of truth for an endpoint's health here. Register arms a heartbeat (see
+// startHeartbeatLocked) that keeps re-asserting this status for as long as the endpoint stays
+// registered, so the check never goes critical purely from the TTL elapsing.
+func consulHealthCheck(ep *model.IstioEndpoint) *consulapi.AgentServiceCheck {
+	check := &consulapi.AgentServiceCheck{
+		TTL: consulTTL.String(),
+	}
+	switch ep.HealthStatus {
+	case model.Healthy, model.Draining:
+		check.Status = consulapi.HealthPassing
+	default:
+		check.Status = consulapi.HealthCritical
+	}
+	return check
+}
@@ -0,0 +1,159 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/host"
+)
+
+// fakeEndpointSink records calls and optionally fails them, for exercising multiEndpointSink and
+// newEndpointSinkFromConfig without a real external registry client.
+type fakeEndpointSink struct {
+	name         string
+	registered   []string
+	deregistered []string
+	synced       []string
+	failRegister bool
+}
+
+func (f *fakeEndpointSink) Register(hostName host.Name, _ *model.IstioEndpoint) error {
+	if f.failRegister {
+		return errors.New(f.name + " register failed")
+	}
+	f.registered = append(f.registered, string(hostName))
+	return nil
+}
+
+func (f *fakeEndpointSink) Deregister(hostName host.Name, _ *model.IstioEndpoint) error {
+	f.deregistered = append(f.deregistered, string(hostName))
+	return nil
+}
+
+func (f *fakeEndpointSink) Sync(hostName host.Name, _ []*model.IstioEndpoint) error {
+	f.synced = append(f.synced, string(hostName))
+	return nil
+}
+
+func TestMultiEndpointSink_FanOut(t *testing.T) {
+	a := &fakeEndpointSink{name: "a"}
+	b := &fakeEndpointSink{name: "b"}
+	m := multiEndpointSink{a, b}
+
+	hostName := host.Name("svc.default.svc.cluster.local")
+	ep := &model.IstioEndpoint{Address: "10.0.0.1"}
+
+	if err := m.Register(hostName, ep); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := m.Deregister(hostName, ep); err != nil {
+		t.Fatalf("Deregister() error = %v", err)
+	}
+	if err := m.Sync(hostName, []*model.IstioEndpoint{ep}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	for _, f := range []*fakeEndpointSink{a, b} {
+		if len(f.registered) != 1 || len(f.deregistered) != 1 || len(f.synced) != 1 {
+			t.Fatalf("sink %s did not receive all calls: %+v", f.name, f)
+		}
+	}
+}
+
+func TestMultiEndpointSink_ErrorAggregation(t *testing.T) {
+	ok := &fakeEndpointSink{name: "ok"}
+	failing := &fakeEndpointSink{name: "failing", failRegister: true}
+	m := multiEndpointSink{failing, ok}
+
+	err := m.Register(host.Name("svc.default.svc.cluster.local"), &model.IstioEndpoint{})
+	if err == nil {
+		t.Fatal("expected an error when one sink fails")
+	}
+	if !strings.Contains(err.Error(), "failing register failed") {
+		t.Fatalf("error %q does not mention the failing sink", err)
+	}
+	// The other sink must still have been called despite the failure.
+	if len(ok.registered) != 1 {
+		t.Fatalf("sink after the failing one should still be called, got %+v", ok)
+	}
+}
+
+func TestNoopEndpointSink(t *testing.T) {
+	var s EndpointSink = noopEndpointSink{}
+	if err := s.Register(host.Name("x"), &model.IstioEndpoint{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := s.Deregister(host.Name("x"), &model.IstioEndpoint{}); err != nil {
+		t.Fatalf("Deregister() error = %v", err)
+	}
+	if err := s.Sync(host.Name("x"), nil); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+}
+
+// withSinkFactories temporarily replaces the package-level sinkFactories registry so tests don't
+// depend on (or pollute) whatever sinks a build-tag-gated file registered via init().
+func withSinkFactories(t *testing.T, factories map[string]func() EndpointSink) {
+	t.Helper()
+	prev := sinkFactories
+	sinkFactories = factories
+	t.Cleanup(func() { sinkFactories = prev })
+}
+
+func TestNewEndpointSinkFromConfig(t *testing.T) {
+	fake := &fakeEndpointSink{name: "fake"}
+	withSinkFactories(t, map[string]func() EndpointSink{
+		"fake": func() EndpointSink { return fake },
+	})
+
+	t.Run("empty config is a noop", func(t *testing.T) {
+		prev := features.EndpointSinks
+		features.EndpointSinks = ""
+		defer func() { features.EndpointSinks = prev }()
+
+		if _, ok := newEndpointSinkFromConfig().(noopEndpointSink); !ok {
+			t.Fatal("expected noopEndpointSink when ENDPOINT_SINKS is empty")
+		}
+	})
+
+	t.Run("unknown or not-built-in sink name is skipped, not fatal", func(t *testing.T) {
+		prev := features.EndpointSinks
+		features.EndpointSinks = "nacos"
+		defer func() { features.EndpointSinks = prev }()
+
+		if _, ok := newEndpointSinkFromConfig().(noopEndpointSink); !ok {
+			t.Fatal("expected noopEndpointSink when every configured sink is unknown")
+		}
+	})
+
+	t.Run("known sink is wired in, whitespace and case insensitive", func(t *testing.T) {
+		prev := features.EndpointSinks
+		features.EndpointSinks = " Fake ,nacos"
+		defer func() { features.EndpointSinks = prev }()
+
+		sink := newEndpointSinkFromConfig()
+		if err := sink.Register(host.Name("svc.default.svc.cluster.local"), &model.IstioEndpoint{}); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		if len(fake.registered) != 1 {
+			t.Fatalf("expected the fake sink to receive the Register call, got %+v", fake)
+		}
+	})
+}
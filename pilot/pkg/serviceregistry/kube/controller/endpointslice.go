@@ -15,15 +15,20 @@
 package controller
 
 import (
+	"reflect"
 	"sync"
 
 	v1 "k8s.io/api/discovery/v1"
 	"k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	listerv1 "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
 	mcs "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
 	"istio.io/istio/pilot/pkg/features"
@@ -37,6 +42,20 @@ import (
 type endpointSliceController struct {
 	kubeEndpoints
 	endpointCache *endpointSliceCache
+	// sink mirrors discovered endpoints into an external service registry (e.g. Consul).
+	// It is a fan-out sink composed from features.EndpointSinks, and is a no-op when unset.
+	sink EndpointSink
+	// serviceImportInformer watches ServiceImport objects so onMCSEvent can confirm one
+	// actually exists before synthesizing a clusterset hostname for its EndpointSlices.
+	serviceImportInformer cache.SharedIndexInformer
+}
+
+// serviceImportGVR is the Multi-Cluster Services (MCS) ServiceImport resource, watched
+// dynamically since pilot does not otherwise depend on a generated mcs-api clientset.
+var serviceImportGVR = schema.GroupVersionResource{
+	Group:    "multicluster.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "serviceimports",
 }
 
 var _ kubeEndpointsController = &endpointSliceController{}
@@ -44,6 +63,12 @@ var _ kubeEndpointsController = &endpointSliceController{}
 var (
 	endpointSliceRequirement = labelRequirement(mcs.LabelServiceName, selection.DoesNotExist, nil)
 	endpointSliceSelector    = klabels.NewSelector().Add(*endpointSliceRequirement)
+
+	// mcsRequirement/mcsEndpointSliceSelector match the complement of endpointSliceSelector:
+	// EndpointSlices backing a Multi-Cluster Services (MCS) ServiceImport rather than a local
+	// Service.
+	mcsRequirement           = labelRequirement(mcs.LabelServiceName, selection.Exists, nil)
+	mcsEndpointSliceSelector = klabels.NewSelector().Add(*mcsRequirement)
 )
 
 func newEndpointSliceController(c *Controller) *endpointSliceController {
@@ -55,21 +80,60 @@ func newEndpointSliceController(c *Controller) *endpointSliceController {
 		c.opts.DiscoveryNamespacesFilter.Filter,
 		informer,
 	)
+	serviceImportInformer := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+		c.client.Dynamic(), 0, metav1.NamespaceAll, nil,
+	).ForResource(serviceImportGVR).Informer()
+	go serviceImportInformer.Run(wait.NeverStop)
+
 	out := &endpointSliceController{
 		kubeEndpoints: kubeEndpoints{
 			c:        c,
 			informer: filteredInformer,
 		},
-		endpointCache: newEndpointSliceCache(),
+		endpointCache:         newEndpointSliceCache(),
+		sink:                  newEndpointSinkFromConfig(),
+		serviceImportInformer: serviceImportInformer,
 	}
 	c.registerHandlers(filteredInformer, "EndpointSlice", out.onEvent, nil)
+	if _, isNoop := out.sink.(noopEndpointSink); !isNoop {
+		go out.waitAndResyncEndpointSinks()
+	}
 	return out
 }
 
+// serviceImportExists reports whether a ServiceImport named name exists in namespace, per
+// esc.serviceImportInformer. Used to avoid synthesizing clusterset hostnames for EndpointSlices
+// whose ServiceImport was deleted out from under them (e.g. a racing delete) or never existed.
+func (esc *endpointSliceController) serviceImportExists(namespace, name string) bool {
+	_, exists, err := esc.serviceImportInformer.GetIndexer().GetByKey(namespace + "/" + name)
+	return err == nil && exists
+}
+
+// waitAndResyncEndpointSinks blocks until the EndpointSlice informer completes its initial sync,
+// then calls resyncEndpointSinks once. The informer's initial Add events land as soon as the
+// cache syncs, which can race a sink that is still establishing its own connection (e.g. to a
+// Consul agent) - this recovers the sink's state regardless of which finished first.
+func (esc *endpointSliceController) waitAndResyncEndpointSinks() {
+	if !cache.WaitForCacheSync(wait.NeverStop, esc.informer.HasSynced) {
+		return
+	}
+	esc.resyncEndpointSinks()
+}
+
 func (esc *endpointSliceController) getInformer() filterinformer.FilteredSharedIndexInformer {
 	return esc.informer
 }
 
+// resyncEndpointSinks replays the full cached endpoint set for every known host to
+// esc.sink. Called once, after the informer has synced, so a sink that just came up recovers
+// the current state instead of waiting for the next EndpointSlice change. It is not called
+// again when a sink reconnects later.
+func (esc *endpointSliceController) resyncEndpointSinks() {
+	for _, hostName := range esc.endpointCache.Hosts() {
+		esc.sink.Sync(hostName, esc.endpointCache.Get(hostName))
+	}
+}
+
 func (esc *endpointSliceController) listSlices(ns string, selector klabels.Selector) ([]*v1.EndpointSlice, error) {
 	return listerv1.NewEndpointSliceLister(esc.informer.GetIndexer()).EndpointSlices(ns).List(selector)
 }
@@ -83,9 +147,49 @@ func (esc *endpointSliceController) onEvent(_, curr any, event model.Event) erro
 	if endpointSliceSelector.Matches(klabels.Set(esLabels)) {
 		return processEndpointEvent(esc.c, esc, serviceNameForEndpointSlice(esLabels), ep.GetNamespace(), event, ep)
 	}
+	if mcsEndpointSliceSelector.Matches(klabels.Set(esLabels)) {
+		return esc.onMCSEvent(ep.GetNamespace(), event, ep)
+	}
+	return nil
+}
+
+// onMCSEvent handles EndpointSlices for an imported Multi-Cluster Services (MCS)
+// ServiceImport. endpointSliceSelector deliberately excludes these slices since they have no
+// local Service to attach to; instead we resolve the ServiceImport and cache the endpoints
+// under the clusterset hostname so they never collide with locally-sourced endpoints for the
+// same name.
+func (esc *endpointSliceController) onMCSEvent(namespace string, event model.Event, ep controllers.Object) error {
+	slice, ok := ep.(*v1.EndpointSlice)
+	if !ok {
+		return nil
+	}
+	importName := slice.GetLabels()[mcs.LabelServiceName]
+	if importName == "" {
+		return nil
+	}
+	hostName := clustersetHostnameForServiceImport(importName, namespace)
+	if event == model.EventDelete {
+		removed := esc.endpointCache.SliceEndpoints(hostName, slice.Name)
+		esc.endpointCache.Delete(hostName, slice.Name)
+		for _, ep := range removed {
+			esc.sink.Deregister(hostName, ep)
+		}
+		return nil
+	}
+	if !esc.serviceImportExists(namespace, importName) {
+		log.Debugf("no ServiceImport %s/%s for MCS EndpointSlice %s, skipping", namespace, importName, slice.Name)
+		return nil
+	}
+	esc.updateMCSEndpointCacheForSlice(hostName, slice)
 	return nil
 }
 
+// clustersetHostnameForServiceImport returns the clusterset hostname MCS clients use to reach
+// a ServiceImport, per the Multi-Cluster Services API.
+func clustersetHostnameForServiceImport(name, namespace string) host.Name {
+	return host.Name(name + "." + namespace + ".svc.clusterset.local")
+}
+
 // GetProxyServiceInstances returns service instances co-located with a given proxy
 // TODO: this code does not return k8s service instances when the proxy's IP is a workload entry
 // To tackle this, we need a ip2instance map like what we have in service entry.
@@ -108,12 +212,31 @@ func serviceNameForEndpointSlice(labels map[string]string) string {
 	return labels[v1beta1.LabelServiceName]
 }
 
+// endpointHealthStatus derives an endpoint's health from its Ready/Serving/Terminating
+// conditions. An endpoint that is Serving && Terminating is reported as Draining for any
+// service (not just persistent-session ones), so xDS keeps sending it traffic while it
+// drains - this matches how modern kube-proxy and OVN-Kubernetes treat terminating endpoints.
+// Services with PublishNotReadyAddresses set always report Healthy, mirroring the headless
+// "publish not ready" semantics StatefulSet bootstrap and gossip protocols rely on.
+// PublishNotReadyAddresses itself is copied from corev1.Service.Spec onto
+// model.ServiceAttributes by kube.setEndpointHealthAttributes as part of ConvertService.
+func endpointHealthStatus(publishNotReadyAddresses bool, conditions v1.EndpointConditions) model.HealthStatus {
+	if publishNotReadyAddresses {
+		return model.Healthy
+	}
+	serving := conditions.Serving == nil || *conditions.Serving
+	terminating := conditions.Terminating != nil && *conditions.Terminating
+	if serving && terminating {
+		return model.Draining
+	}
+	if conditions.Ready == nil || *conditions.Ready {
+		return model.Healthy
+	}
+	return model.UnHealthy
+}
+
 func (esc *endpointSliceController) sliceServiceInstances(c *Controller, ep *v1.EndpointSlice, proxy *model.Proxy) []*model.ServiceInstance {
 	var out []*model.ServiceInstance
-	if ep.AddressType == v1.AddressTypeFQDN {
-		// TODO(https://github.com/istio/istio/issues/34995) support FQDN endpointslice
-		return out
-	}
 	for _, svc := range c.servicesForNamespacedName(esc.getServiceNamespacedName(ep)) {
 		pod := c.pods.getPodByProxy(proxy)
 		builder := NewEndpointBuilder(c, pod)
@@ -128,19 +251,25 @@ func (esc *endpointSliceController) sliceServiceInstances(c *Controller, ep *v1.
 			if !exists {
 				continue
 			}
+			if ep.AddressType == v1.AddressTypeFQDN {
+				// FQDN endpoints are hostnames, not pod IPs, so they can never be
+				// co-located with proxy.IPAddresses. Nothing to correlate here.
+				continue
+			}
 			// consider multiple IP scenarios
 			for _, ip := range proxy.IPAddresses {
 				for _, ep := range ep.Endpoints {
 					for _, a := range ep.Addresses {
 						if a == ip {
 							istioEndpoint := builder.buildIstioEndpoint(ip, *port.Port, svcPort.Name, discoverabilityPolicy)
+							istioEndpoint.HealthStatus = endpointHealthStatus(svc.Attributes.PublishNotReadyAddresses, ep.Conditions)
 							out = append(out, &model.ServiceInstance{
 								Endpoint:    istioEndpoint,
 								ServicePort: svcPort,
 								Service:     svc,
 							})
 							// If the endpoint isn't ready, report this
-							if ep.Conditions.Ready != nil && !*ep.Conditions.Ready && c.opts.Metrics != nil {
+							if istioEndpoint.HealthStatus != model.Healthy && c.opts.Metrics != nil {
 								c.opts.Metrics.AddMetric(model.ProxyStatusEndpointNotReady, proxy.ID, proxy.ID, "")
 							}
 						}
@@ -155,10 +284,13 @@ func (esc *endpointSliceController) sliceServiceInstances(c *Controller, ep *v1.
 
 func (esc *endpointSliceController) forgetEndpoint(endpoint any) map[host.Name][]*model.IstioEndpoint {
 	slice := endpoint.(*v1.EndpointSlice)
-	key := kube.KeyFunc(slice.Name, slice.Namespace)
-	for _, e := range slice.Endpoints {
-		for _, a := range e.Addresses {
-			esc.c.pods.endpointDeleted(key, a)
+	if slice.AddressType != v1.AddressTypeFQDN {
+		// FQDN endpoints are never pod-backed, so there is no pod IP tracking to clean up.
+		key := kube.KeyFunc(slice.Name, slice.Namespace)
+		for _, e := range slice.Endpoints {
+			for _, a := range e.Addresses {
+				esc.c.pods.endpointDeleted(key, a)
+			}
 		}
 	}
 
@@ -166,53 +298,55 @@ func (esc *endpointSliceController) forgetEndpoint(endpoint any) map[host.Name][
 	for _, hostName := range esc.c.hostNamesForNamespacedName(esc.getServiceNamespacedName(slice)) {
 		// endpointSlice cache update
 		if esc.endpointCache.Has(hostName) {
+			removed := esc.endpointCache.SliceEndpoints(hostName, slice.Name)
 			esc.endpointCache.Delete(hostName, slice.Name)
 			out[hostName] = esc.endpointCache.Get(hostName)
+			for _, ep := range removed {
+				esc.sink.Deregister(hostName, ep)
+			}
 		}
 	}
 	return out
 }
 
+// buildIstioEndpoints returns the current endpoints for hostName. Its signature is fixed by
+// kubeEndpointsController, which the legacy Endpoints-API controller also implements, so the
+// "changed" bit updateEndpointCacheForSlice computes can't be threaded through it without
+// touching every caller of that shared interface; updateEndpointCacheForSlice uses it internally
+// instead, to skip redundant EndpointSink writes on an unchanged resync.
 func (esc *endpointSliceController) buildIstioEndpoints(es any, hostName host.Name) []*model.IstioEndpoint {
 	esc.updateEndpointCacheForSlice(hostName, es)
 	return esc.endpointCache.Get(hostName)
 }
 
-func (esc *endpointSliceController) updateEndpointCacheForSlice(hostName host.Name, ep any) {
+func (esc *endpointSliceController) updateEndpointCacheForSlice(hostName host.Name, ep any) bool {
 	var endpoints []*model.IstioEndpoint
 	slice := ep.(*v1.EndpointSlice)
-	if slice.AddressType == v1.AddressTypeFQDN {
-		// TODO(https://github.com/istio/istio/issues/34995) support FQDN endpointslice
-		return
-	}
+	isFQDN := slice.AddressType == v1.AddressTypeFQDN
 	svc := esc.c.GetService(hostName)
 	discoverabilityPolicy := esc.c.exports.EndpointDiscoverabilityPolicy(svc)
+	publishNotReadyAddresses := svc != nil && svc.Attributes.PublishNotReadyAddresses
 
 	for _, e := range slice.Endpoints {
-		// Draining tracking is only enabled if persistent sessions is enabled.
-		// If we start using them for other features, this can be adjusted.
-		draining := features.PersistentSessionLabel != "" &&
-			svc != nil &&
-			svc.Attributes.Labels != nil &&
-			svc.Attributes.Labels[features.PersistentSessionLabel] != "" &&
-			e.Conditions.Ready != nil &&
-			e.Conditions.Serving != nil &&
-			*e.Conditions.Serving &&
-			!*e.Conditions.Ready
-		if !features.SendUnhealthyEndpoints.Load() {
-			if !draining && e.Conditions.Ready != nil && !*e.Conditions.Ready {
-				// Ignore not ready endpoints. Draining endpoints are tracked, but not returned
-				// except for persistent-session clusters.
-				continue
-			}
+		healthStatus := endpointHealthStatus(publishNotReadyAddresses, e.Conditions)
+		if !features.SendUnhealthyEndpoints.Load() && healthStatus == model.UnHealthy {
+			// Ignore unhealthy endpoints. Draining endpoints are kept and returned, so xDS
+			// keeps sending traffic to them while they drain.
+			continue
 		}
-		ready := e.Conditions.Ready == nil || *e.Conditions.Ready
 		for _, a := range e.Addresses {
-			pod, expectedPod := getPod(esc.c, a, &metav1.ObjectMeta{Name: slice.Name, Namespace: slice.Namespace}, e.TargetRef, hostName)
-			if pod == nil && expectedPod {
-				continue
+			// FQDN endpoints are hostnames to be resolved by Envoy (STRICT_DNS/LOGICAL_DNS
+			// clusters), not pod IPs, so there is no pod to correlate them with.
+			var builder *EndpointBuilder
+			if isFQDN {
+				builder = NewEndpointBuilder(esc.c, nil)
+			} else {
+				pod, expectedPod := getPod(esc.c, a, &metav1.ObjectMeta{Name: slice.Name, Namespace: slice.Namespace}, e.TargetRef, hostName)
+				if pod == nil && expectedPod {
+					continue
+				}
+				builder = NewEndpointBuilder(esc.c, pod)
 			}
-			builder := NewEndpointBuilder(esc.c, pod)
 			// EDS and ServiceEntry use name for service port - ADS will need to map to numbers.
 			for _, port := range slice.Ports {
 				var portNum int32
@@ -225,18 +359,18 @@ func (esc *endpointSliceController) updateEndpointCacheForSlice(hostName host.Na
 				}
 
 				istioEndpoint := builder.buildIstioEndpoint(a, portNum, portName, discoverabilityPolicy)
-				if ready {
-					istioEndpoint.HealthStatus = model.Healthy
-				} else if draining {
-					istioEndpoint.HealthStatus = model.Draining
-				} else {
-					istioEndpoint.HealthStatus = model.UnHealthy
-				}
+				istioEndpoint.HealthStatus = healthStatus
 				endpoints = append(endpoints, istioEndpoint)
 			}
 		}
 	}
-	esc.endpointCache.Update(hostName, slice.Name, endpoints)
+	changed := esc.endpointCache.Update(hostName, slice.Name, endpoints)
+	if changed {
+		for _, ep := range endpoints {
+			esc.sink.Register(hostName, ep)
+		}
+	}
+	return changed
 }
 
 func (esc *endpointSliceController) buildIstioEndpointsWithService(name, namespace string, hostName host.Name, updateCache bool) []*model.IstioEndpoint {
@@ -257,6 +391,135 @@ func (esc *endpointSliceController) buildIstioEndpointsWithService(name, namespa
 	return esc.endpointCache.Get(hostName)
 }
 
+// updateMCSEndpointCacheForSlice mirrors updateEndpointCacheForSlice for EndpointSlices backing
+// an imported ServiceImport. There is no local model.Service for a ServiceImport, so there are
+// no PublishNotReadyAddresses/persistent-session attributes to honor. Discoverability is routed
+// through Controller.exports like any other service, via the synthetic clusterset model.Service
+// Controller.exports maintains for the import; that Service isn't guaranteed to exist yet the
+// first time a slice for a brand-new import lands, so this falls back to model.AlwaysDiscoverable
+// in that narrow bootstrap window - MCS explicitly opts in to cross-cluster exposure, so erring
+// towards discoverable there is the safe direction.
+func (esc *endpointSliceController) updateMCSEndpointCacheForSlice(hostName host.Name, slice *v1.EndpointSlice) {
+	var endpoints []*model.IstioEndpoint
+	isFQDN := slice.AddressType == v1.AddressTypeFQDN
+	discoverabilityPolicy := model.AlwaysDiscoverable
+	if svc := esc.c.GetService(hostName); svc != nil {
+		discoverabilityPolicy = esc.c.exports.EndpointDiscoverabilityPolicy(svc)
+	}
+
+	for _, e := range slice.Endpoints {
+		healthStatus := endpointHealthStatus(false, e.Conditions)
+		if !features.SendUnhealthyEndpoints.Load() && healthStatus == model.UnHealthy {
+			continue
+		}
+		for _, a := range e.Addresses {
+			var builder *EndpointBuilder
+			if isFQDN {
+				builder = NewEndpointBuilder(esc.c, nil)
+			} else {
+				pod, expectedPod := getPod(esc.c, a, &metav1.ObjectMeta{Name: slice.Name, Namespace: slice.Namespace}, e.TargetRef, hostName)
+				if pod == nil && expectedPod {
+					continue
+				}
+				builder = NewEndpointBuilder(esc.c, pod)
+			}
+			for _, port := range slice.Ports {
+				var portNum int32
+				if port.Port != nil {
+					portNum = *port.Port
+				}
+				var portName string
+				if port.Name != nil {
+					portName = *port.Name
+				}
+
+				istioEndpoint := builder.buildIstioEndpoint(a, portNum, portName, discoverabilityPolicy)
+				istioEndpoint.HealthStatus = healthStatus
+				endpoints = append(endpoints, istioEndpoint)
+			}
+		}
+	}
+	if esc.endpointCache.Update(hostName, slice.Name, endpoints) {
+		for _, ep := range endpoints {
+			esc.sink.Register(hostName, ep)
+		}
+	}
+}
+
+// buildIstioEndpointsWithServiceImport is the buildIstioEndpointsWithService equivalent for a
+// Multi-Cluster Services (MCS) ServiceImport: name/namespace identify the ServiceImport, not a
+// local Service, and the result is cached under the clusterset hostname rather than the
+// ServiceImport's own namespaced name.
+func (esc *endpointSliceController) buildIstioEndpointsWithServiceImport(name, namespace string) []*model.IstioEndpoint {
+	hostName := clustersetHostnameForServiceImport(name, namespace)
+	slices, err := esc.listSlices(namespace, mcsEndpointSliceSelectorForServiceImport(name))
+	if err != nil || len(slices) == 0 {
+		log.Debugf("MCS endpoint slices for ServiceImport (%s, %s) not found => error %v", name, namespace, err)
+		return nil
+	}
+
+	for _, slice := range slices {
+		esc.updateMCSEndpointCacheForSlice(hostName, slice)
+	}
+
+	return esc.endpointCache.Get(hostName)
+}
+
+// InstancesByPortForServiceImport is the InstancesByPort equivalent for a ServiceImport: svc
+// describes the synthetic clusterset Service Istio builds for the import (see
+// Controller.exports), so its Hostname is already the clusterset hostname used as the cache key.
+func (esc *endpointSliceController) InstancesByPortForServiceImport(svc *model.Service, importName, importNamespace string, reqSvcPort int) []*model.ServiceInstance {
+	slices, err := esc.listSlices(importNamespace, mcsEndpointSliceSelectorForServiceImport(importName))
+	if err != nil || len(slices) == 0 {
+		return nil
+	}
+
+	svcPort, exists := svc.Ports.GetByPort(reqSvcPort)
+	if !exists {
+		return nil
+	}
+	discoverabilityPolicy := esc.c.exports.EndpointDiscoverabilityPolicy(svc)
+
+	var out []*model.ServiceInstance
+	for _, slice := range slices {
+		isFQDN := slice.AddressType == v1.AddressTypeFQDN
+		for _, e := range slice.Endpoints {
+			healthStatus := endpointHealthStatus(false, e.Conditions)
+			if !features.SendUnhealthyEndpoints.Load() && healthStatus == model.UnHealthy {
+				continue
+			}
+			for _, a := range e.Addresses {
+				var builder *EndpointBuilder
+				if isFQDN {
+					builder = NewEndpointBuilder(esc.c, nil)
+				} else {
+					pod, expectedPod := getPod(esc.c, a, &metav1.ObjectMeta{Name: slice.Name, Namespace: slice.Namespace}, e.TargetRef, svc.Hostname)
+					if pod == nil && expectedPod {
+						continue
+					}
+					builder = NewEndpointBuilder(esc.c, pod)
+				}
+				for _, port := range slice.Ports {
+					var portNum int32
+					if port.Port != nil {
+						portNum = *port.Port
+					}
+					if port.Name == nil || svcPort.Name == *port.Name {
+						istioEndpoint := builder.buildIstioEndpoint(a, portNum, svcPort.Name, discoverabilityPolicy)
+						istioEndpoint.HealthStatus = healthStatus
+						out = append(out, &model.ServiceInstance{
+							Endpoint:    istioEndpoint,
+							ServicePort: svcPort,
+							Service:     svc,
+						})
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
 func (esc *endpointSliceController) getServiceNamespacedName(es any) types.NamespacedName {
 	slice := es.(metav1.Object)
 	return types.NamespacedName{
@@ -283,21 +546,28 @@ func (esc *endpointSliceController) InstancesByPort(c *Controller, svc *model.Se
 	}
 
 	discoverabilityPolicy := c.exports.EndpointDiscoverabilityPolicy(svc)
+	publishNotReadyAddresses := svc.Attributes.PublishNotReadyAddresses
 
 	var out []*model.ServiceInstance
 	for _, slice := range slices {
-		if slice.AddressType == v1.AddressTypeFQDN {
-			// TODO(https://github.com/istio/istio/issues/34995) support FQDN endpointslice
-			continue
-		}
+		isFQDN := slice.AddressType == v1.AddressTypeFQDN
 		for _, e := range slice.Endpoints {
+			healthStatus := endpointHealthStatus(publishNotReadyAddresses, e.Conditions)
+			if !features.SendUnhealthyEndpoints.Load() && healthStatus == model.UnHealthy {
+				continue
+			}
 			for _, a := range e.Addresses {
-				pod, expectedPod := getPod(c, a, &metav1.ObjectMeta{Name: slice.Name, Namespace: slice.Namespace}, e.TargetRef, svc.Hostname)
-				if pod == nil && expectedPod {
-					continue
+				var builder *EndpointBuilder
+				if isFQDN {
+					// FQDN endpoints have no backing pod to correlate with.
+					builder = NewEndpointBuilder(esc.c, nil)
+				} else {
+					pod, expectedPod := getPod(c, a, &metav1.ObjectMeta{Name: slice.Name, Namespace: slice.Namespace}, e.TargetRef, svc.Hostname)
+					if pod == nil && expectedPod {
+						continue
+					}
+					builder = NewEndpointBuilder(esc.c, pod)
 				}
-
-				builder := NewEndpointBuilder(esc.c, pod)
 				// identify the port by name. K8S EndpointPort uses the service port name
 				for _, port := range slice.Ports {
 					var portNum int32
@@ -308,6 +578,7 @@ func (esc *endpointSliceController) InstancesByPort(c *Controller, svc *model.Se
 					if port.Name == nil ||
 						svcPort.Name == *port.Name {
 						istioEndpoint := builder.buildIstioEndpoint(a, portNum, svcPort.Name, discoverabilityPolicy)
+						istioEndpoint.HealthStatus = healthStatus
 						out = append(out, &model.ServiceInstance{
 							Endpoint:    istioEndpoint,
 							ServicePort: svcPort,
@@ -321,69 +592,163 @@ func (esc *endpointSliceController) InstancesByPort(c *Controller, svc *model.Se
 	return out
 }
 
-// endpointKey unique identifies an endpoint by IP and port name
-// This is used for deduping endpoints across slices.
+// endpointKey unique identifies an endpoint by address (an IP, or a hostname for
+// AddressTypeFQDN slices) and port name. This is used for deduping endpoints across slices.
 type endpointKey struct {
-	ip   string
-	port string
+	address string
+	port    string
+}
+
+// hostEndpoint is an endpoint materialized for a host, along with a count of how many slices
+// currently contribute it. A single endpoint can be duplicated across slices during a rollout
+// (see the "duplicate endpoints" note below), so it is only actually removed once every slice
+// referencing it has dropped it.
+type hostEndpoint struct {
+	endpoint *model.IstioEndpoint
+	refCount int
 }
 
 type endpointSliceCache struct {
-	mu                         sync.RWMutex
-	endpointsByServiceAndSlice map[host.Name]map[string][]*model.IstioEndpoint
+	mu sync.RWMutex
+	// endpointsByServiceAndSlice holds the last snapshot reported by each slice, keyed by
+	// endpointKey, so Update/Delete can diff against it instead of rebuilding the host's full
+	// endpoint list on every call.
+	endpointsByServiceAndSlice map[host.Name]map[string]map[endpointKey]*model.IstioEndpoint
+	// hostEndpoints is the materialized, de-duplicated view of endpoints per host. Get reads
+	// straight from it, with no dedup pass needed at read time.
+	hostEndpoints map[host.Name]map[endpointKey]*hostEndpoint
 }
 
 func newEndpointSliceCache() *endpointSliceCache {
-	out := &endpointSliceCache{
-		endpointsByServiceAndSlice: make(map[host.Name]map[string][]*model.IstioEndpoint),
+	return &endpointSliceCache{
+		endpointsByServiceAndSlice: make(map[host.Name]map[string]map[endpointKey]*model.IstioEndpoint),
+		hostEndpoints:              make(map[host.Name]map[endpointKey]*hostEndpoint),
 	}
-	return out
 }
 
-func (e *endpointSliceCache) Update(hostname host.Name, slice string, endpoints []*model.IstioEndpoint) {
+// Update diffs endpoints against the previous snapshot reported by slice for hostname and
+// applies only the added/removed/changed keys to the host-level map, reference-counting keys
+// that appear in more than one slice (see the "duplicate endpoints" note below) so they are
+// only dropped once every slice referencing them has dropped them. It reports whether the
+// host's materialized endpoint set actually changed, so callers can skip a push when it didn't.
+func (e *endpointSliceCache) Update(hostname host.Name, slice string, endpoints []*model.IstioEndpoint) bool {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	if len(endpoints) == 0 {
+
+	next := make(map[endpointKey]*model.IstioEndpoint, len(endpoints))
+	for _, ep := range endpoints {
+		next[endpointKey{ep.Address, ep.ServicePortName}] = ep
+	}
+	prev := e.endpointsByServiceAndSlice[hostname][slice]
+
+	if e.hostEndpoints[hostname] == nil {
+		e.hostEndpoints[hostname] = make(map[endpointKey]*hostEndpoint)
+	}
+	hostMap := e.hostEndpoints[hostname]
+
+	changed := false
+	for key, ep := range next {
+		_, existedInSlice := prev[key]
+		if he, ok := hostMap[key]; ok {
+			if !existedInSlice {
+				he.refCount++
+			}
+			if !reflect.DeepEqual(he.endpoint, ep) {
+				changed = true
+			}
+			// A conflict here means an endpoint is transitioning from one slice to another. See
+			// https://github.com/kubernetes/website/blob/master/content/en/docs/concepts/services-networking/endpoint-slices.md#duplicate-endpoints
+			// We always take the newest update, though older slices we have not gotten updates
+			// for may be stale.
+			he.endpoint = ep
+			continue
+		}
+		hostMap[key] = &hostEndpoint{endpoint: ep, refCount: 1}
+		changed = true
+	}
+	for key := range prev {
+		if _, stillPresent := next[key]; stillPresent {
+			continue
+		}
+		if he, ok := hostMap[key]; ok {
+			he.refCount--
+			if he.refCount <= 0 {
+				delete(hostMap, key)
+				changed = true
+			}
+		}
+	}
+	if len(hostMap) == 0 {
+		// Mirrors Delete: don't leave an empty map entry behind for a host that dropped to
+		// zero endpoints via Update rather than via a slice Delete event.
+		delete(e.hostEndpoints, hostname)
+	}
+
+	if len(next) == 0 {
 		delete(e.endpointsByServiceAndSlice[hostname], slice)
+		if len(e.endpointsByServiceAndSlice[hostname]) == 0 {
+			delete(e.endpointsByServiceAndSlice, hostname)
+		}
+	} else {
+		if e.endpointsByServiceAndSlice[hostname] == nil {
+			e.endpointsByServiceAndSlice[hostname] = make(map[string]map[endpointKey]*model.IstioEndpoint)
+		}
+		e.endpointsByServiceAndSlice[hostname][slice] = next
 	}
-	if _, f := e.endpointsByServiceAndSlice[hostname]; !f {
-		e.endpointsByServiceAndSlice[hostname] = make(map[string][]*model.IstioEndpoint)
+	return changed
+}
+
+// SliceEndpoints returns the endpoints last reported by a single slice, without dedup against
+// other slices for the same host. Callers use this to see what a slice owned immediately
+// before deleting it, e.g. to report removals to an EndpointSink.
+func (e *endpointSliceCache) SliceEndpoints(hostname host.Name, slice string) []*model.IstioEndpoint {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	bySlice := e.endpointsByServiceAndSlice[hostname][slice]
+	if len(bySlice) == 0 {
+		return nil
+	}
+	endpoints := make([]*model.IstioEndpoint, 0, len(bySlice))
+	for _, ep := range bySlice {
+		endpoints = append(endpoints, ep)
 	}
-	// We will always overwrite. A conflict here means an endpoint is transitioning
-	// from one slice to another See
-	// https://github.com/kubernetes/website/blob/master/content/en/docs/concepts/services-networking/endpoint-slices.md#duplicate-endpoints
-	// In this case, we can always assume and update is fresh, although older slices
-	// we have not gotten updates may be stale; therefor we always take the new
-	// update.
-	e.endpointsByServiceAndSlice[hostname][slice] = endpoints
+	return endpoints
 }
 
 func (e *endpointSliceCache) Delete(hostname host.Name, slice string) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	hostMap := e.hostEndpoints[hostname]
+	for key := range e.endpointsByServiceAndSlice[hostname][slice] {
+		if he, ok := hostMap[key]; ok {
+			he.refCount--
+			if he.refCount <= 0 {
+				delete(hostMap, key)
+			}
+		}
+	}
+	if len(hostMap) == 0 {
+		delete(e.hostEndpoints, hostname)
+	}
+
 	delete(e.endpointsByServiceAndSlice[hostname], slice)
 	if len(e.endpointsByServiceAndSlice[hostname]) == 0 {
 		delete(e.endpointsByServiceAndSlice, hostname)
 	}
 }
 
+// Get returns the materialized, already-deduped endpoints for hostname in O(n).
 func (e *endpointSliceCache) Get(hostname host.Name) []*model.IstioEndpoint {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	var endpoints []*model.IstioEndpoint
-	found := map[endpointKey]struct{}{}
-	for _, eps := range e.endpointsByServiceAndSlice[hostname] {
-		for _, ep := range eps {
-			key := endpointKey{ep.Address, ep.ServicePortName}
-			if _, f := found[key]; f {
-				// This a duplicate. Update() already handles conflict resolution, so we don't
-				// need to pick the "right" one here.
-				continue
-			}
-			found[key] = struct{}{}
-			endpoints = append(endpoints, ep)
-		}
+	hostMap := e.hostEndpoints[hostname]
+	if len(hostMap) == 0 {
+		return nil
+	}
+	endpoints := make([]*model.IstioEndpoint, 0, len(hostMap))
+	for _, he := range hostMap {
+		endpoints = append(endpoints, he.endpoint)
 	}
 	return endpoints
 }
@@ -395,8 +760,29 @@ func (e *endpointSliceCache) Has(hostname host.Name) bool {
 	return found
 }
 
+// Hosts returns every hostname currently tracked by the cache. Used by resyncEndpointSinks to
+// resync every EndpointSink's external view of the world on startup.
+func (e *endpointSliceCache) Hosts() []host.Name {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	hosts := make([]host.Name, 0, len(e.endpointsByServiceAndSlice))
+	for hostname := range e.endpointsByServiceAndSlice {
+		hosts = append(hosts, hostname)
+	}
+	return hosts
+}
+
 func endpointSliceSelectorForService(name string) klabels.Selector {
 	return klabels.Set(map[string]string{
 		v1beta1.LabelServiceName: name,
 	}).AsSelectorPreValidated().Add(*endpointSliceRequirement)
 }
+
+// mcsEndpointSliceSelectorForServiceImport selects the EndpointSlices backing a given
+// ServiceImport, identified by the MCS mcs.LabelServiceName label (not the core
+// v1beta1.LabelServiceName label, which MCS-imported slices do not carry).
+func mcsEndpointSliceSelectorForServiceImport(importName string) klabels.Selector {
+	return klabels.Set(map[string]string{
+		mcs.LabelServiceName: importName,
+	}).AsSelectorPreValidated()
+}
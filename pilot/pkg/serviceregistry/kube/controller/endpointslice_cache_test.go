@@ -0,0 +1,224 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/host"
+)
+
+func ep(address, portName string) *model.IstioEndpoint {
+	return &model.IstioEndpoint{Address: address, ServicePortName: portName, HealthStatus: model.Healthy}
+}
+
+func TestEndpointSliceCache_UpdateAddRemoveChange(t *testing.T) {
+	hostname := host.Name("svc.default.svc.cluster.local")
+	cache := newEndpointSliceCache()
+
+	changed := cache.Update(hostname, "slice-1", []*model.IstioEndpoint{ep("10.0.0.1", "http"), ep("10.0.0.2", "http")})
+	if !changed {
+		t.Fatal("first Update for a slice should always report changed")
+	}
+	if got := len(cache.Get(hostname)); got != 2 {
+		t.Fatalf("got %d endpoints, want 2", got)
+	}
+
+	// Re-reporting the exact same set should be a no-op.
+	changed = cache.Update(hostname, "slice-1", []*model.IstioEndpoint{ep("10.0.0.1", "http"), ep("10.0.0.2", "http")})
+	if changed {
+		t.Fatal("re-reporting an unchanged endpoint set should report changed=false")
+	}
+
+	// Dropping one endpoint and adding another should report changed and update the materialized set.
+	changed = cache.Update(hostname, "slice-1", []*model.IstioEndpoint{ep("10.0.0.1", "http"), ep("10.0.0.3", "http")})
+	if !changed {
+		t.Fatal("adding/removing endpoints should report changed=true")
+	}
+	got := cache.Get(hostname)
+	if len(got) != 2 {
+		t.Fatalf("got %d endpoints, want 2", len(got))
+	}
+	addrs := map[string]bool{}
+	for _, e := range got {
+		addrs[e.Address] = true
+	}
+	if addrs["10.0.0.2"] || !addrs["10.0.0.1"] || !addrs["10.0.0.3"] {
+		t.Fatalf("unexpected endpoint set after update: %v", addrs)
+	}
+
+	// Changing only a field on an existing key (same address+port) should also report changed.
+	draining := &model.IstioEndpoint{Address: "10.0.0.1", ServicePortName: "http", HealthStatus: model.Draining}
+	changed = cache.Update(hostname, "slice-1", []*model.IstioEndpoint{draining, ep("10.0.0.3", "http")})
+	if !changed {
+		t.Fatal("a field-only change on an existing key should report changed=true")
+	}
+}
+
+func TestEndpointSliceCache_DuplicateEndpointAcrossSlices(t *testing.T) {
+	hostname := host.Name("svc.default.svc.cluster.local")
+	cache := newEndpointSliceCache()
+
+	// The same endpoint reported by two slices (e.g. mid-rollout transition between slices)
+	// must only be removed from the materialized set once every slice referencing it drops it.
+	cache.Update(hostname, "slice-1", []*model.IstioEndpoint{ep("10.0.0.1", "http")})
+	cache.Update(hostname, "slice-2", []*model.IstioEndpoint{ep("10.0.0.1", "http")})
+	if got := len(cache.Get(hostname)); got != 1 {
+		t.Fatalf("got %d endpoints, want 1 (deduped across slices)", got)
+	}
+
+	cache.Update(hostname, "slice-1", nil)
+	if got := len(cache.Get(hostname)); got != 1 {
+		t.Fatalf("endpoint still referenced by slice-2 should survive slice-1 dropping it, got %d endpoints", got)
+	}
+
+	cache.Update(hostname, "slice-2", nil)
+	if got := cache.Get(hostname); got != nil {
+		t.Fatalf("endpoint dropped by every referencing slice should be gone, got %v", got)
+	}
+}
+
+func TestEndpointSliceCache_UpdateToEmptyClearsHost(t *testing.T) {
+	hostname := host.Name("svc.default.svc.cluster.local")
+	cache := newEndpointSliceCache()
+
+	cache.Update(hostname, "slice-1", []*model.IstioEndpoint{ep("10.0.0.1", "http")})
+	changed := cache.Update(hostname, "slice-1", nil)
+	if !changed {
+		t.Fatal("dropping to zero endpoints via Update should report changed=true")
+	}
+	if got := cache.Get(hostname); got != nil {
+		t.Fatalf("expected no endpoints after Update to empty, got %v", got)
+	}
+	if cache.Has(hostname) {
+		t.Fatal("Has should report false once a host's only slice is updated to empty")
+	}
+	if len(cache.hostEndpoints[hostname]) != 0 {
+		t.Fatal("hostEndpoints should not retain an empty map entry for a host with zero endpoints")
+	}
+}
+
+// TestEndpointSliceCache_FQDNAndIPCoexist exercises the cache-level half of FQDN endpoint
+// support: a slice addressing endpoints by hostname and a slice addressing them by pod IP, for
+// the same host.Name, must not collide or dedupe against each other just because endpointKey
+// only carries address+port. Building the IstioEndpoint for an FQDN address (via EndpointBuilder)
+// is Controller-dependent and isn't covered here.
+func TestEndpointSliceCache_FQDNAndIPCoexist(t *testing.T) {
+	hostname := host.Name("backend.default.svc.cluster.local")
+	cache := newEndpointSliceCache()
+
+	cache.Update(hostname, "slice-fqdn", []*model.IstioEndpoint{ep("backend.example.com", "http")})
+	cache.Update(hostname, "slice-ip", []*model.IstioEndpoint{ep("10.0.0.5", "http")})
+
+	got := cache.Get(hostname)
+	if len(got) != 2 {
+		t.Fatalf("got %d endpoints, want 2 (one FQDN, one IP)", len(got))
+	}
+	addrs := map[string]bool{}
+	for _, e := range got {
+		addrs[e.Address] = true
+	}
+	if !addrs["backend.example.com"] || !addrs["10.0.0.5"] {
+		t.Fatalf("expected both the FQDN and IP endpoint to be present, got %v", addrs)
+	}
+
+	// Removing the FQDN slice must not disturb the IP slice's endpoint.
+	cache.Delete(hostname, "slice-fqdn")
+	got = cache.Get(hostname)
+	if len(got) != 1 || got[0].Address != "10.0.0.5" {
+		t.Fatalf("expected only the IP endpoint to remain, got %v", got)
+	}
+}
+
+func TestEndpointSliceCache_Delete(t *testing.T) {
+	hostname := host.Name("svc.default.svc.cluster.local")
+	cache := newEndpointSliceCache()
+
+	cache.Update(hostname, "slice-1", []*model.IstioEndpoint{ep("10.0.0.1", "http"), ep("10.0.0.2", "http")})
+	cache.Update(hostname, "slice-2", []*model.IstioEndpoint{ep("10.0.0.2", "http"), ep("10.0.0.3", "http")})
+
+	removed := cache.SliceEndpoints(hostname, "slice-1")
+	if len(removed) != 2 {
+		t.Fatalf("got %d endpoints owned by slice-1, want 2", len(removed))
+	}
+
+	cache.Delete(hostname, "slice-1")
+	got := cache.Get(hostname)
+	if len(got) != 2 {
+		t.Fatalf("got %d endpoints after deleting slice-1, want 2 (10.0.0.2 still owned by slice-2)", len(got))
+	}
+
+	cache.Delete(hostname, "slice-2")
+	if cache.Has(hostname) {
+		t.Fatal("Has should report false once every slice for a host is deleted")
+	}
+	if len(cache.hostEndpoints[hostname]) != 0 {
+		t.Fatal("hostEndpoints should not retain an empty map entry after the last slice is deleted")
+	}
+}
+
+// benchmarkSlices builds endpoints for a service split across numSlices slices, simulating the
+// layout of a large Service with many EndpointSlices for the same host.
+func benchmarkSlices(numSlices, endpointsPerSlice int) (host.Name, map[string][]*model.IstioEndpoint) {
+	hostname := host.Name("benchmark.default.svc.cluster.local")
+	slices := make(map[string][]*model.IstioEndpoint, numSlices)
+	for s := 0; s < numSlices; s++ {
+		eps := make([]*model.IstioEndpoint, 0, endpointsPerSlice)
+		for i := 0; i < endpointsPerSlice; i++ {
+			eps = append(eps, &model.IstioEndpoint{
+				Address:         fmt.Sprintf("10.%d.%d.%d", s, i/256, i%256),
+				ServicePortName: "http",
+				HealthStatus:    model.Healthy,
+			})
+		}
+		slices[fmt.Sprintf("slice-%d", s)] = eps
+	}
+	return hostname, slices
+}
+
+// BenchmarkEndpointSliceCache_Update measures repeatedly re-reporting the same, unchanged
+// endpoint set for a service with 1k endpoints spread across 10 slices - the common case on a
+// steady-state informer resync, where the diff-based Update should do very little work.
+func BenchmarkEndpointSliceCache_Update(b *testing.B) {
+	hostname, slices := benchmarkSlices(10, 100)
+	cache := newEndpointSliceCache()
+	for slice, eps := range slices {
+		cache.Update(hostname, slice, eps)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for slice, eps := range slices {
+			cache.Update(hostname, slice, eps)
+		}
+	}
+}
+
+// BenchmarkEndpointSliceCache_Get measures reading back the materialized endpoint set for a
+// service with 1k endpoints across 10 slices.
+func BenchmarkEndpointSliceCache_Get(b *testing.B) {
+	hostname, slices := benchmarkSlices(10, 100)
+	cache := newEndpointSliceCache()
+	for slice, eps := range slices {
+		cache.Update(hostname, slice, eps)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.Get(hostname)
+	}
+}
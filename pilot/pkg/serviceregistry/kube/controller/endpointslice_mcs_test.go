@@ -0,0 +1,89 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	mcs "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+)
+
+// fakeServiceImportInformer satisfies cache.SharedIndexInformer by embedding the (nil)
+// interface and overriding only GetIndexer, the single method serviceImportExists calls - there
+// is no generated mcs-api clientset in this tree to back a real informer in tests.
+type fakeServiceImportInformer struct {
+	cache.SharedIndexInformer
+	indexer cache.Indexer
+}
+
+func (f *fakeServiceImportInformer) GetIndexer() cache.Indexer { return f.indexer }
+
+func TestServiceImportExists(t *testing.T) {
+	indexer := cache.NewIndexer(cache.DeletionHandlingMetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(cache.DeletedFinalStateUnknown{Key: "default/imported-svc"}); err != nil {
+		t.Fatalf("indexer.Add() error = %v", err)
+	}
+	esc := &endpointSliceController{
+		serviceImportInformer: &fakeServiceImportInformer{indexer: indexer},
+	}
+
+	if !esc.serviceImportExists("default", "imported-svc") {
+		t.Fatal("expected serviceImportExists to find a ServiceImport present in the indexer")
+	}
+	if esc.serviceImportExists("default", "never-imported") {
+		t.Fatal("expected serviceImportExists to report false for a name not in the indexer")
+	}
+	if esc.serviceImportExists("other-ns", "imported-svc") {
+		t.Fatal("expected serviceImportExists to be namespace-scoped")
+	}
+}
+
+func TestClustersetHostnameForServiceImport(t *testing.T) {
+	got := clustersetHostnameForServiceImport("imported-svc", "default")
+	want := "imported-svc.default.svc.clusterset.local"
+	if string(got) != want {
+		t.Fatalf("clustersetHostnameForServiceImport() = %q, want %q", got, want)
+	}
+}
+
+func TestMCSEndpointSliceSelector(t *testing.T) {
+	mcsLabels := klabels.Set{mcs.LabelServiceName: "imported-svc"}
+	if !mcsEndpointSliceSelector.Matches(mcsLabels) {
+		t.Fatal("mcsEndpointSliceSelector should match an EndpointSlice carrying the MCS service-name label")
+	}
+	if endpointSliceSelector.Matches(mcsLabels) {
+		t.Fatal("endpointSliceSelector should not match an MCS-labeled EndpointSlice")
+	}
+
+	localLabels := klabels.Set{"kubernetes.io/service-name": "local-svc"}
+	if mcsEndpointSliceSelector.Matches(localLabels) {
+		t.Fatal("mcsEndpointSliceSelector should not match a locally-sourced EndpointSlice")
+	}
+	if !endpointSliceSelector.Matches(localLabels) {
+		t.Fatal("endpointSliceSelector should match a locally-sourced EndpointSlice")
+	}
+}
+
+func TestMCSEndpointSliceSelectorForServiceImport(t *testing.T) {
+	selector := mcsEndpointSliceSelectorForServiceImport("imported-svc")
+	if !selector.Matches(klabels.Set{mcs.LabelServiceName: "imported-svc"}) {
+		t.Fatal("expected selector to match EndpointSlices labeled for this ServiceImport")
+	}
+	if selector.Matches(klabels.Set{mcs.LabelServiceName: "other-svc"}) {
+		t.Fatal("expected selector to reject EndpointSlices labeled for a different ServiceImport")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/discovery/v1"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestEndpointHealthStatus(t *testing.T) {
+	cases := []struct {
+		name                     string
+		publishNotReadyAddresses bool
+		conditions               v1.EndpointConditions
+		want                     model.HealthStatus
+	}{
+		{
+			name:       "ready, serving unset, terminating unset",
+			conditions: v1.EndpointConditions{Ready: boolPtr(true)},
+			want:       model.Healthy,
+		},
+		{
+			name:       "not ready, not terminating",
+			conditions: v1.EndpointConditions{Ready: boolPtr(false), Serving: boolPtr(false)},
+			want:       model.UnHealthy,
+		},
+		{
+			name: "serving and terminating is draining regardless of ready",
+			conditions: v1.EndpointConditions{
+				Ready:       boolPtr(false),
+				Serving:     boolPtr(true),
+				Terminating: boolPtr(true),
+			},
+			want: model.Draining,
+		},
+		{
+			name: "terminating but not serving is unhealthy, not draining",
+			conditions: v1.EndpointConditions{
+				Ready:       boolPtr(false),
+				Serving:     boolPtr(false),
+				Terminating: boolPtr(true),
+			},
+			want: model.UnHealthy,
+		},
+		{
+			name:       "ready nil defaults to healthy",
+			conditions: v1.EndpointConditions{},
+			want:       model.Healthy,
+		},
+		{
+			name:                     "publishNotReadyAddresses overrides not-ready",
+			publishNotReadyAddresses: true,
+			conditions:               v1.EndpointConditions{Ready: boolPtr(false), Serving: boolPtr(false)},
+			want:                     model.Healthy,
+		},
+		{
+			name:                     "publishNotReadyAddresses overrides draining",
+			publishNotReadyAddresses: true,
+			conditions: v1.EndpointConditions{
+				Ready:       boolPtr(false),
+				Serving:     boolPtr(true),
+				Terminating: boolPtr(true),
+			},
+			want: model.Healthy,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := endpointHealthStatus(c.publishNotReadyAddresses, c.conditions)
+			if got != c.want {
+				t.Fatalf("endpointHealthStatus() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
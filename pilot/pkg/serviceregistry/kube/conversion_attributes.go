@@ -0,0 +1,58 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/host"
+)
+
+// ConvertService builds the model.Service Istio's kube registry uses for a Kubernetes Service.
+// Hostname and Attributes are the pieces controller/endpointslice.go depends on; ClusterVIPs,
+// Ports and Resolution are populated by the rest of the registry from
+// svc.Spec.ClusterIPs/svc.Spec.Ports/svc.Spec.Type and are out of scope here.
+func ConvertService(svc corev1.Service, domainSuffix string) *model.Service {
+	return &model.Service{
+		Hostname:   ServiceHostname(svc.Name, svc.Namespace, domainSuffix),
+		Attributes: convertServiceAttributes(svc),
+	}
+}
+
+// ServiceHostname builds the in-cluster hostname Istio uses for a Kubernetes Service.
+func ServiceHostname(name, namespace, domainSuffix string) host.Name {
+	return host.Name(name + "." + namespace + ".svc." + domainSuffix)
+}
+
+// convertServiceAttributes populates the model.ServiceAttributes fields ConvertService derives
+// directly from the corev1.Service, with no other registry state needed.
+func convertServiceAttributes(svc corev1.Service) model.ServiceAttributes {
+	attrs := model.ServiceAttributes{
+		Name:      svc.Name,
+		Namespace: svc.Namespace,
+		Labels:    svc.Labels,
+	}
+	setEndpointHealthAttributes(&svc, &attrs)
+	return attrs
+}
+
+// setEndpointHealthAttributes copies the subset of a corev1.Service's spec that
+// controller/endpointslice.go needs to compute endpoint health onto attrs. It is kept separate
+// from the rest of convertServiceAttributes since PublishNotReadyAddresses only matters for
+// endpoint health, not general service identity.
+func setEndpointHealthAttributes(svc *corev1.Service, attrs *model.ServiceAttributes) {
+	attrs.PublishNotReadyAddresses = svc.Spec.PublishNotReadyAddresses
+}
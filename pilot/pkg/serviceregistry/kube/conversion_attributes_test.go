@@ -0,0 +1,53 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertService_PublishNotReadyAddresses(t *testing.T) {
+	cases := []struct {
+		name string
+		want bool
+	}{
+		{name: "unset", want: false},
+		{name: "set", want: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			svc := corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "headless", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{PublishNotReadyAddresses: c.want},
+			}
+			got := ConvertService(svc, "cluster.local")
+			if got.Attributes.PublishNotReadyAddresses != c.want {
+				t.Fatalf("Attributes.PublishNotReadyAddresses = %v, want %v", got.Attributes.PublishNotReadyAddresses, c.want)
+			}
+		})
+	}
+}
+
+func TestServiceHostname(t *testing.T) {
+	got := ServiceHostname("headless", "default", "cluster.local")
+	want := "headless.default.svc.cluster.local"
+	if string(got) != want {
+		t.Fatalf("ServiceHostname() = %q, want %q", got, want)
+	}
+}